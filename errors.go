@@ -0,0 +1,21 @@
+package gini
+
+import "fmt"
+
+//
+// keyNotFoundError is returned by the typed accessors when a key does not
+// exist, in place of the strconv/time error they return for a value that
+// does not parse.
+//
+type keyNotFoundError struct {
+	section string
+	key     string
+}
+
+func newKeyNotFoundError(section, key string) error {
+	return &keyNotFoundError{section: section, key: key}
+}
+
+func (e *keyNotFoundError) Error() string {
+	return fmt.Sprintf("gini: key %q not found in section %q", e.key, e.section)
+}
@@ -0,0 +1,161 @@
+package gini
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+//
+// maxExpandDepth bounds how many levels of value-to-value interpolation
+// Read will follow before giving up and reporting an error.
+//
+const maxExpandDepth = 10
+
+//
+// placeholderPattern matches both interpolation syntaxes Read understands:
+// "${key}" / "${section:key}", and the configparser-style "%(key)s".
+//
+var placeholderPattern = regexp.MustCompile(`\$\{([^}]*)\}|%\(([^)]*)\)s`)
+
+//
+// WithExpander installs a lookup hook used when a "${key}" (or "%(key)s")
+// placeholder isn't a key in the referenced section and isn't set as an
+// environment variable - for example to resolve it against a secrets
+// store. It returns f for chaining. Installing a hook also turns value
+// expansion on for f, even if it was loaded without LoadOptions.Expand.
+//
+func (f *Ini) WithExpander(fn func(name string) (string, bool)) *Ini {
+	f.expander = fn
+	f.expandEnabled = true
+	return f
+}
+
+//
+// TryRead is Read, but surfaces value-expansion failures instead of
+// silently falling back to the raw value: if expansion is enabled (see
+// WithExpander/LoadOptions.Expand) and the value contains a placeholder
+// cycle or nests deeper than maxExpandDepth, TryRead returns that error.
+// A missing section or key is not an error: it returns ("", nil), like
+// Read returning "".
+//
+func (f *Ini) TryRead(sectionName, keyName string) (string, error) {
+	value, foundSection, ok := f.lookupRaw(sectionName, keyName)
+	if !ok {
+		return "", nil
+	}
+
+	if !f.expandEnabled {
+		return value, nil
+	}
+
+	return f.expand(foundSection, value, 0, nil)
+}
+
+//
+// expandIfEnabled resolves "${...}"/"%(...)s" placeholders in value when
+// expansion is enabled, falling back to the raw value if expansion hits a
+// cycle or exceeds maxExpandDepth.
+//
+func (f *Ini) expandIfEnabled(sectionName, value string) string {
+	if !f.expandEnabled {
+		return value
+	}
+
+	expanded, err := f.expand(sectionName, value, 0, nil)
+	if err != nil {
+		return value
+	}
+
+	return expanded
+}
+
+//
+// expand resolves every placeholder in value, recursively expanding
+// referenced values too. stack holds the "section:key" identifiers already
+// being expanded on the current path, to detect cycles.
+//
+func (f *Ini) expand(sectionName, value string, depth int, stack map[string]bool) (string, error) {
+	if depth > maxExpandDepth {
+		return "", fmt.Errorf("gini: value expansion exceeded max depth of %d", maxExpandDepth)
+	}
+
+	matches := placeholderPattern.FindAllStringSubmatchIndex(value, -1)
+	if matches == nil {
+		return value, nil
+	}
+
+	var out strings.Builder
+	last := 0
+
+	for _, m := range matches {
+		out.WriteString(value[last:m[0]])
+
+		var name string
+		if m[2] >= 0 {
+			name = value[m[2]:m[3]]
+		} else {
+			name = value[m[4]:m[5]]
+		}
+
+		resolved, err := f.resolvePlaceholder(sectionName, name, depth, stack)
+		if err != nil {
+			return "", err
+		}
+
+		out.WriteString(resolved)
+		last = m[1]
+	}
+
+	out.WriteString(value[last:])
+
+	return out.String(), nil
+}
+
+//
+// resolvePlaceholder looks up a single placeholder's replacement: a
+// "section:key" or bare "key" reference to another value in f, falling
+// back to an environment variable and then to f.expander. An unresolved
+// placeholder expands to "".
+//
+func (f *Ini) resolvePlaceholder(sectionName, name string, depth int, stack map[string]bool) (string, error) {
+	sect := sectionName
+	key := name
+
+	if i := strings.Index(name, ":"); i >= 0 {
+		sect = strings.TrimSpace(name[:i])
+		key = strings.TrimSpace(name[i+1:])
+	} else {
+		key = strings.TrimSpace(name)
+	}
+
+	id := sect + ":" + key
+	if stack[id] {
+		return "", fmt.Errorf("gini: cycle detected while expanding %q", id)
+	}
+
+	if s, fnd := f.getSection(sect); fnd {
+		if e, fnd := s.get(key); fnd {
+			next := make(map[string]bool, len(stack)+1)
+			for k, v := range stack {
+				next[k] = v
+			}
+			next[id] = true
+
+			return f.expand(sect, e.value, depth+1, next)
+		}
+	}
+
+	if v, ok := os.LookupEnv(key); ok {
+		return v, nil
+	}
+
+	if f.expander != nil {
+		if v, ok := f.expander(key); ok {
+			return v, nil
+		}
+	}
+
+	return "", nil
+}
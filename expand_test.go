@@ -0,0 +1,123 @@
+package gini
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+//
+// TestExpandEnvVar
+//
+func TestExpandEnvVar(t *testing.T) {
+	os.Setenv("GINI_TEST_HOME", "/home/gini")
+	defer os.Unsetenv("GINI_TEST_HOME")
+
+	src := "[a]\nhome = ${GINI_TEST_HOME}\nlegacy = %(GINI_TEST_HOME)s\n"
+
+	opts := DefaultLoadOptions()
+	opts.Expand = true
+
+	ini, err := LoadReaderWithOptions(strings.NewReader(src), opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	chkkey(t, ini, "a", "home", "/home/gini")
+	chkkey(t, ini, "a", "legacy", "/home/gini")
+}
+
+//
+// TestExpandCrossKey
+//
+func TestExpandCrossKey(t *testing.T) {
+	src := "[a]\nname = bob\ngreeting = hello ${name}\n[b]\nwhois = ${a:name}\n"
+
+	opts := DefaultLoadOptions()
+	opts.Expand = true
+
+	ini, err := LoadReaderWithOptions(strings.NewReader(src), opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	chkkey(t, ini, "a", "greeting", "hello bob")
+	chkkey(t, ini, "b", "whois", "bob")
+}
+
+//
+// TestExpandDisabledLeavesPlaceholdersLiteral
+//
+func TestExpandDisabledLeavesPlaceholdersLiteral(t *testing.T) {
+	src := "[a]\nname = ${nope}\n"
+
+	ini, err := LoadReader(strings.NewReader(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	chkkey(t, ini, "a", "name", "${nope}")
+}
+
+//
+// TestWithExpanderHook
+//
+func TestWithExpanderHook(t *testing.T) {
+	src := "[a]\nsecret = ${db-password}\n"
+
+	ini, err := LoadReader(strings.NewReader(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ini.WithExpander(func(name string) (string, bool) {
+		if name == "db-password" {
+			return "s3cr3t", true
+		}
+		return "", false
+	})
+
+	chkkey(t, ini, "a", "secret", "s3cr3t")
+}
+
+//
+// TestExpandCycleDetection
+//
+func TestExpandCycleDetection(t *testing.T) {
+	src := "[a]\nx = ${y}\ny = ${x}\n"
+
+	opts := DefaultLoadOptions()
+	opts.Expand = true
+
+	ini, err := LoadReaderWithOptions(strings.NewReader(src), opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Read can't return an error, so it falls back to the raw value
+	if v := ini.Read("a", "x"); v != "${y}" {
+		t.Errorf("expect Read to fall back to the raw value, got %q", v)
+	}
+
+	// TryRead surfaces the cycle instead of swallowing it
+	if _, err := ini.TryRead("a", "x"); err == nil {
+		t.Error("expected TryRead to report a cycle error")
+	}
+}
+
+//
+// TestExpandMissingPlaceholderResolvesEmpty
+//
+func TestExpandMissingPlaceholderResolvesEmpty(t *testing.T) {
+	src := "[a]\nx = prefix-${nope}-suffix\n"
+
+	opts := DefaultLoadOptions()
+	opts.Expand = true
+
+	ini, err := LoadReaderWithOptions(strings.NewReader(src), opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	chkkey(t, ini, "a", "x", "prefix--suffix")
+}
@@ -46,170 +46,221 @@ SOFTWARE.
 package gini
 
 import (
-	"bufio"
-	"errors"
 	"io"
-	"os"
-	"strconv"
 	"strings"
 )
 
 //
-// Ini configuration type
+// entry is a single key/value pair, along with the comment and blank lines
+// that appeared directly above it in the source file. Keeping those lines
+// around lets a load-modify-save round-trip reproduce them.
 //
-type Ini struct {
-	sections map[string]keys
+type entry struct {
+	key      string
+	value    string
+	comments []string
 }
 
-type keys map[string]string
-
 //
-// Read a value from configuration with specified sectionName and keyName.
-// Return "" if section or key not found
+// section is an ordered collection of entries, along with the comment and
+// blank lines that appeared directly above its `[name]` header.
 //
-func (f *Ini) Read(sectionName, keyName string) string {
-	keys := f.sections[sectionName]
-	if keys == nil {
-		return ""
+// parent holds the part of name before the last '.', following the
+// `parent.child` section inheritance convention (see inherit.go); it is
+// empty for a top-level section.
+//
+type section struct {
+	name     string
+	parent   string
+	entries  []*entry
+	index    map[string]int
+	comments []string
+}
+
+func newSection(name string) *section {
+	s := &section{
+		name:  name,
+		index: make(map[string]int),
+	}
+
+	if i := strings.LastIndex(name, "."); i >= 0 {
+		s.parent = name[:i]
 	}
 
-	return keys[keyName]
+	return s
 }
 
-//
-// SectionExists check whether a section exists.
-// Return `true` if the section exists
-//
-func (f *Ini) SectionExists(sectionName string) bool {
-	_, fnd := f.sections[sectionName]
-	return fnd
+func (s *section) get(keyName string) (*entry, bool) {
+	i, fnd := s.index[keyName]
+	if !fnd {
+		return nil, false
+	}
+	return s.entries[i], true
 }
 
-//
-// KeyExists check whether a key in a section exists.
-// Return `true` if the key exists
-//
-func (f *Ini) KeyExists(sectionName, keyName string) bool {
-	sect, fnd := f.sections[sectionName]
+func (s *section) set(keyName, value string, comments []string) *entry {
+	if i, fnd := s.index[keyName]; fnd {
+		e := s.entries[i]
+		e.value = value
+		return e
+	}
+
+	e := &entry{key: keyName, value: value, comments: comments}
+	s.index[keyName] = len(s.entries)
+	s.entries = append(s.entries, e)
+	return e
+}
+
+func (s *section) delete(keyName string) bool {
+	i, fnd := s.index[keyName]
 	if !fnd {
 		return false
 	}
 
-	_, fnd = sect[keyName]
-	return fnd
+	s.entries = append(s.entries[:i], s.entries[i+1:]...)
+	delete(s.index, keyName)
+	for k, idx := range s.index {
+		if idx > i {
+			s.index[k] = idx - 1
+		}
+	}
+	return true
 }
 
-//
-// SectionList list of available sections.
-// Return `array of string` contains available sections
-//
-func (f *Ini) SectionList() []string {
+func (s *section) keyList() []string {
 	var lst []string
-
-	for k := range f.sections {
-		lst = append(lst, k)
+	for _, e := range s.entries {
+		lst = append(lst, e.key)
 	}
-
 	return lst
 }
 
 //
-// KeyList list of keys on a specified section.
-// Return `array of string` contains available keys in that section
+// Ini configuration type
 //
-func (f *Ini) KeyList(sectionName string) []string {
-	var lst []string
+type Ini struct {
+	sections []*section
+	index    map[string]int
+	trailing []string
+
+	// expandEnabled and expander back Read's value expansion; see expand.go.
+	expandEnabled bool
+	expander      func(name string) (string, bool)
+}
 
-	sect, fnd := f.sections[sectionName]
+func (f *Ini) getSection(sectionName string) (*section, bool) {
+	if f.index == nil {
+		return nil, false
+	}
+	i, fnd := f.index[sectionName]
 	if !fnd {
-		return lst
+		return nil, false
+	}
+	return f.sections[i], true
+}
+
+func (f *Ini) getOrCreateSection(sectionName string) *section {
+	if s, fnd := f.getSection(sectionName); fnd {
+		return s
 	}
 
-	for k := range sect {
-		lst = append(lst, k)
+	if f.index == nil {
+		f.index = make(map[string]int)
 	}
 
-	return lst
+	s := newSection(sectionName)
+	f.index[sectionName] = len(f.sections)
+	f.sections = append(f.sections, s)
+	return s
 }
 
 //
-// parseIni parse ini string in `Reader`.
-// Return map of sections
-// Also return error if occured while reading and parsing the INI. On successful, error is nil
+// Read a value from configuration with specified sectionName and keyName.
+// If sectionName follows the `parent.child` convention and the key is not
+// present in it, Read falls back to the parent section, and so on up the
+// chain (see inherit.go). Return "" if the key is not found anywhere in
+// the chain. If value expansion is enabled (see expand.go) and expanding
+// the value fails - a cycle or too much recursion - Read returns the raw,
+// unexpanded value; use TryRead to observe that error instead.
 //
-func parseIni(in *bufio.Reader) (map[string]keys, error) {
-	var data = make(map[string]keys)
-	var sectionName string = ""
-	var done = false
-	var lineNumber = 0
-
-	for !done {
-		line, err := in.ReadString('\n')
-		if err != nil {
-			if err == io.EOF {
-				done = true
-			} else {
-				return nil, err
-			}
-		}
-		lineNumber++
-
-		// remove comment
-		n := strings.IndexAny(line, "#;")
-		if n >= 0 {
-			line = line[0:n]
-		}
-
-		// trim the line
-		line = strings.TrimSpace(line)
-		//log.Println(">" + line)
+func (f *Ini) Read(sectionName, keyName string) string {
+	value, foundSection, ok := f.lookupRaw(sectionName, keyName)
+	if !ok {
+		return ""
+	}
 
-		// skip blank line
-		if len(line) == 0 {
-			continue
-		}
+	return f.expandIfEnabled(foundSection, value)
+}
 
-		ln := len(line)
+//
+// lookupRaw walks sectionName up its `parent.child` chain (see inherit.go)
+// looking for keyName, without applying value expansion. It returns the
+// raw value, the name of the section it was actually found in (which may
+// be an ancestor of sectionName), and whether it was found at all.
+//
+func (f *Ini) lookupRaw(sectionName, keyName string) (string, string, bool) {
+	name := sectionName
 
-		// section
-		if line[0] == '[' {
-			if ln <= 2 || line[ln-1] != ']' {
-				return nil, errors.New("Invalid section at line " + strconv.Itoa(lineNumber))
+	for {
+		if sect, fnd := f.getSection(name); fnd {
+			if e, fnd := sect.get(keyName); fnd {
+				return e.value, name, true
 			}
-
-			sectionName = strings.TrimSpace(strings.ToLower(line[1 : ln-1]))
-			//log.Println(">> section: " + sectionName)
-			continue
 		}
 
-		// key
-		n = strings.IndexRune(line, '=')
-		if n < 0 {
-			return nil, errors.New("Invalid format at line " + strconv.Itoa(lineNumber))
+		i := strings.LastIndex(name, ".")
+		if i < 0 {
+			return "", "", false
 		}
+		name = name[:i]
+	}
+}
 
-		if sectionName == "" {
-			return nil, errors.New("Key without section at line " + strconv.Itoa(lineNumber))
-		}
+//
+// SectionExists check whether a section exists.
+// Return `true` if the section exists
+//
+func (f *Ini) SectionExists(sectionName string) bool {
+	_, fnd := f.getSection(sectionName)
+	return fnd
+}
 
-		keyName := strings.ToLower(strings.TrimSpace(line[:n]))
-		keyValue := strings.TrimSpace(line[n+1:])
-		//log.Println(">> key: " + name + ", value: " + value )
+//
+// KeyExists check whether a key in a section exists. Like Read, this
+// follows the `parent.child` fallback chain (see inherit.go), so a key
+// declared only in an ancestor section is reported as existing too.
+// Return `true` if the key exists
+//
+func (f *Ini) KeyExists(sectionName, keyName string) bool {
+	_, _, fnd := f.lookupRaw(sectionName, keyName)
+	return fnd
+}
 
-		if keyName == "" {
-			return nil, errors.New("Empty key at line " + strconv.Itoa(lineNumber))
-		}
+//
+// SectionList list of available sections, in the order they were declared.
+// Return `array of string` contains available sections
+//
+func (f *Ini) SectionList() []string {
+	var lst []string
 
-		section, fnd := data[sectionName]
-		if !fnd {
-			section = make(map[string]string)
-			data[sectionName] = section
-		}
+	for _, s := range f.sections {
+		lst = append(lst, s.name)
+	}
 
-		section[keyName] = keyValue
+	return lst
+}
+
+//
+// KeyList list of keys on a specified section, in the order they were declared.
+// Return `array of string` contains available keys in that section
+//
+func (f *Ini) KeyList(sectionName string) []string {
+	sect, fnd := f.getSection(sectionName)
+	if !fnd {
+		return nil
 	}
 
-	return data, nil
+	return sect.keyList()
 }
 
 //
@@ -218,18 +269,7 @@ func parseIni(in *bufio.Reader) (map[string]keys, error) {
 // Return error if occured while reading and parsing the INI. On successful, error is nil
 //
 func LoadReader(in io.Reader) (*Ini, error) {
-	bufin, ok := in.(*bufio.Reader)
-	if !ok {
-		bufin = bufio.NewReader(in)
-	}
-	data, err := parseIni(bufin)
-	if err != nil {
-		return nil, err
-	}
-
-	f := new(Ini)
-	f.sections = data
-	return f, nil
+	return LoadReaderWithOptions(in, DefaultLoadOptions())
 }
 
 //
@@ -238,11 +278,5 @@ func LoadReader(in io.Reader) (*Ini, error) {
 // Return error if occured while reading and parsing the INI. On successful, error is nil
 //
 func LoadFile(path string) (*Ini, error) {
-	in, err := os.Open(path)
-	if err != nil {
-		return nil, err
-	}
-	defer in.Close()
-
-	return LoadReader(in)
+	return LoadFileWithOptions(path, DefaultLoadOptions())
 }
@@ -0,0 +1,32 @@
+package gini
+
+//
+// ChildSections returns the sections directly nested under parent using
+// the `parent.child` naming convention, in declaration order. A section
+// "db.replica.west" is a child of "db.replica", not of "db".
+//
+func (f *Ini) ChildSections(parent string) []string {
+	var lst []string
+
+	for _, s := range f.sections {
+		if s.parent == parent {
+			lst = append(lst, s.name)
+		}
+	}
+
+	return lst
+}
+
+//
+// ReadWithFallback is Read with a default: it returns def whenever Read
+// returns "" - when sectionName, and every section it falls back to up the
+// `parent.child` chain, has no such key, and also when the value found is
+// empty.
+//
+func (f *Ini) ReadWithFallback(sectionName, keyName, def string) string {
+	if v := f.Read(sectionName, keyName); v != "" {
+		return v
+	}
+
+	return def
+}
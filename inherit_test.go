@@ -0,0 +1,81 @@
+package gini
+
+import (
+	"strings"
+	"testing"
+)
+
+var inheritIni = `
+[db]
+host = base-host
+timeout = 5
+
+[db.replica]
+host = replica-host
+
+[db.replica.west]
+host = west-host
+`
+
+//
+// TestReadFallsBackToParentSection
+//
+func TestReadFallsBackToParentSection(t *testing.T) {
+	ini, err := LoadReader(strings.NewReader(inheritIni))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	chkkey(t, ini, "db.replica", "host", "replica-host")
+	chkkey(t, ini, "db.replica", "timeout", "5")
+	chkkey(t, ini, "db.replica.west", "host", "west-host")
+	chkkey(t, ini, "db.replica.west", "timeout", "5")
+
+	if v := ini.Read("db.unknown", "timeout"); v != "5" {
+		t.Errorf("expect fallback to db even for an undeclared child section, got %q", v)
+	}
+	if v := ini.Read("nope", "timeout"); v != "" {
+		t.Errorf("expect \"\" for a key that doesn't exist anywhere, got %q", v)
+	}
+}
+
+//
+// TestChildSections
+//
+func TestChildSections(t *testing.T) {
+	ini, err := LoadReader(strings.NewReader(inheritIni))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = sliceCompare(ini.ChildSections("db"), []string{"db.replica"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = sliceCompare(ini.ChildSections("db.replica"), []string{"db.replica.west"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := ini.ChildSections("nope"); got != nil {
+		t.Errorf("expect nil children for an unknown parent, got %v", got)
+	}
+}
+
+//
+// TestReadWithFallback
+//
+func TestReadWithFallback(t *testing.T) {
+	ini, err := LoadReader(strings.NewReader(inheritIni))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if v := ini.ReadWithFallback("db.replica", "host", "default-host"); v != "replica-host" {
+		t.Errorf("expect replica-host, got %q", v)
+	}
+	if v := ini.ReadWithFallback("db.replica", "missing", "default-value"); v != "default-value" {
+		t.Errorf("expect default-value, got %q", v)
+	}
+}
@@ -0,0 +1,77 @@
+package gini
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+)
+
+//
+// Load parses each source in order and merges them into a single *Ini,
+// with later sources overriding earlier ones at the section/key level.
+// Each source must be a file path (string), []byte, or io.Reader - for
+// example Load("app.ini", "app.local.ini") layers a base config with an
+// environment-specific override.
+//
+func Load(sources ...interface{}) (*Ini, error) {
+	f := NewIni()
+
+	if err := f.Append(sources...); err != nil {
+		return nil, err
+	}
+
+	return f, nil
+}
+
+//
+// Append parses each source in order and merges it into f, with later
+// sources overriding earlier ones at the section/key level.
+//
+func (f *Ini) Append(sources ...interface{}) error {
+	for i, src := range sources {
+		other, err := loadSource(src)
+		if err != nil {
+			return fmt.Errorf("gini: source %d: %w", i, err)
+		}
+
+		f.Merge(other)
+	}
+
+	return nil
+}
+
+//
+// loadSource parses a single source of the kinds accepted by Load/Append.
+//
+func loadSource(src interface{}) (*Ini, error) {
+	switch v := src.(type) {
+	case string:
+		return LoadFile(v)
+	case []byte:
+		return LoadReader(bytes.NewReader(v))
+	case io.Reader:
+		return LoadReader(v)
+	default:
+		return nil, errors.New("unsupported source type")
+	}
+}
+
+//
+// Merge copies every section and key from other into f, overriding any
+// key that already exists in f. Sections and keys that f does not have yet
+// are appended, preserving other's declaration order.
+//
+func (f *Ini) Merge(other *Ini) {
+	if other == nil {
+		return
+	}
+
+	for _, os := range other.sections {
+		sect := f.getOrCreateSection(os.name)
+
+		for _, oe := range os.entries {
+			sect.set(oe.key, oe.value, oe.comments)
+		}
+	}
+}
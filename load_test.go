@@ -0,0 +1,101 @@
+package gini
+
+import (
+	"strings"
+	"testing"
+)
+
+//
+// TestLoadLaterSourceWins
+//
+func TestLoadLaterSourceWins(t *testing.T) {
+	base := []byte("[server]\nhost = base-host\nport = 8080\n")
+	override := "[server]\nhost = local-host\n"
+
+	ini, err := Load(base, strings.NewReader(override))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	chkkey(t, ini, "server", "host", "local-host")
+	chkkey(t, ini, "server", "port", "8080")
+}
+
+//
+// TestLoadFilePath checks that a string source is treated as a file path.
+//
+func TestLoadFilePath(t *testing.T) {
+	path := t.TempDir() + "/base.ini"
+
+	base := NewIni()
+	base.Set("server", "host", "file-host")
+	if err := base.SaveFile(path); err != nil {
+		t.Fatal(err)
+	}
+
+	ini, err := Load(path, []byte("[server]\nport = 9090\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	chkkey(t, ini, "server", "host", "file-host")
+	chkkey(t, ini, "server", "port", "9090")
+}
+
+//
+// TestLoadUnsupportedSource
+//
+func TestLoadUnsupportedSource(t *testing.T) {
+	if _, err := Load(42); err == nil {
+		t.Error("expected an error for an unsupported source type")
+	}
+}
+
+//
+// TestAppend
+//
+func TestAppend(t *testing.T) {
+	ini := NewIni()
+	ini.Set("a", "x", "1")
+
+	if err := ini.Append([]byte("[a]\nx = 2\ny = 3\n")); err != nil {
+		t.Fatal(err)
+	}
+
+	chkkey(t, ini, "a", "x", "2")
+	chkkey(t, ini, "a", "y", "3")
+}
+
+//
+// TestMerge
+//
+func TestMerge(t *testing.T) {
+	base, err := LoadReader(strings.NewReader("[a]\nx = 1\ny = 2\n[b]\nz = 3\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	extra, err := LoadReader(strings.NewReader("[a]\ny = 20\n[c]\nw = 4\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	base.Merge(extra)
+
+	chkkey(t, base, "a", "x", "1")
+	chkkey(t, base, "a", "y", "20")
+	chkkey(t, base, "b", "z", "3")
+	chkkey(t, base, "c", "w", "4")
+}
+
+//
+// TestMergeNil checks that merging a nil *Ini is a no-op.
+//
+func TestMergeNil(t *testing.T) {
+	ini := NewIni()
+	ini.Set("a", "x", "1")
+
+	ini.Merge(nil)
+
+	chkkey(t, ini, "a", "x", "1")
+}
@@ -0,0 +1,299 @@
+package gini
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+//
+// LoadOptions controls how LoadReaderWithOptions and LoadFileWithOptions
+// parse an INI source. The zero value turns every option off (with "=" as
+// the only delimiter), which is not the same as the defaults; start from
+// DefaultLoadOptions and override only what differs.
+//
+type LoadOptions struct {
+	// CaseSensitive keeps section and key names as written instead of
+	// lower-casing them. Off by default, for backward compatibility -
+	// but that default corrupts values such as Linux file paths used as
+	// section/key names, so callers that round-trip files should turn it on.
+	CaseSensitive bool
+
+	// KeyValueDelimiters is the set of characters that separate a key from
+	// its value, tried in the order they appear in the line. Defaults to
+	// "=" only; set to "=:" to also accept "key: value" style lines.
+	KeyValueDelimiters string
+
+	// AllowInlineComment strips a trailing "# ..." or "; ..." from a value.
+	// On by default, matching gini's original behaviour. Disable it when
+	// values may legitimately contain '#' or ';', such as URLs with a
+	// fragment.
+	AllowInlineComment bool
+
+	// AllowPythonMultilineValues appends a line that starts with
+	// whitespace to the previous key's value, separated by a newline,
+	// the same continuation convention Python's configparser uses.
+	AllowPythonMultilineValues bool
+
+	// AllowQuotedValues takes a value wrapped in matching double or
+	// single quotes verbatim: surrounding whitespace, '#'/';' and the
+	// escapes \n, \t, \\ and \" (or \') are interpreted, and nothing
+	// inside the quotes is treated as a comment. On by default: WriteTo
+	// quotes any value that needs it (see writer.go), and that quoting
+	// must round-trip back through the default load path or a
+	// load-modify-save cycle would silently corrupt the value.
+	AllowQuotedValues bool
+
+	// Expand turns on ${key}, ${section:key} and %(key)s interpolation in
+	// values read with Read; see expand.go.
+	Expand bool
+}
+
+//
+// DefaultLoadOptions returns the options used by LoadReader and LoadFile:
+// case-insensitive names, "=" as the only delimiter, inline comments
+// stripped, no multiline continuation, and quoted values understood. This
+// matches gini's behaviour prior to the introduction of LoadOptions, except
+// that quoted values are now recognized - needed so that a value WriteTo
+// had to quote (see writer.go) reads back correctly through the default
+// load path.
+//
+func DefaultLoadOptions() LoadOptions {
+	return LoadOptions{
+		KeyValueDelimiters: "=",
+		AllowInlineComment: true,
+		AllowQuotedValues:  true,
+	}
+}
+
+//
+// LoadReaderWithOptions is LoadReader with configurable parsing behaviour.
+// See LoadOptions.
+//
+func LoadReaderWithOptions(in io.Reader, opts LoadOptions) (*Ini, error) {
+	bufin, ok := in.(*bufio.Reader)
+	if !ok {
+		bufin = bufio.NewReader(in)
+	}
+
+	return parseIni(bufin, opts)
+}
+
+//
+// LoadFileWithOptions is LoadFile with configurable parsing behaviour.
+// See LoadOptions.
+//
+func LoadFileWithOptions(path string, opts LoadOptions) (*Ini, error) {
+	in, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer in.Close()
+
+	return LoadReaderWithOptions(in, opts)
+}
+
+//
+// parseIni parses ini content from `in` according to opts.
+// Return *Ini with sections and keys in declaration order, including the
+// comment and blank lines attached to each section and key.
+// Also return error if occured while reading and parsing the INI. On successful, error is nil
+//
+func parseIni(in *bufio.Reader, opts LoadOptions) (*Ini, error) {
+	delims := opts.KeyValueDelimiters
+	if delims == "" {
+		delims = "="
+	}
+
+	f := &Ini{index: make(map[string]int)}
+	var curSection *section
+	var lastEntry *entry
+	var pending []string
+	var done = false
+	var lineNumber = 0
+
+	fold := func(s string) string {
+		if opts.CaseSensitive {
+			return s
+		}
+		return strings.ToLower(s)
+	}
+
+	for !done {
+		rawLine, err := in.ReadString('\n')
+		if err != nil {
+			if err == io.EOF {
+				done = true
+			} else {
+				return nil, err
+			}
+		}
+		lineNumber++
+
+		rawLine = strings.TrimRight(rawLine, "\r\n")
+		trimmed := strings.TrimSpace(rawLine)
+
+		// blank line or comment-only line: keep it pending for the next
+		// section/key, so a save reproduces it
+		if trimmed == "" {
+			if rawLine != "" || !done {
+				pending = append(pending, rawLine)
+			}
+			lastEntry = nil
+			continue
+		}
+		if trimmed[0] == '#' || trimmed[0] == ';' {
+			pending = append(pending, rawLine)
+			lastEntry = nil
+			continue
+		}
+
+		// continuation of the previous key's value
+		if opts.AllowPythonMultilineValues && lastEntry != nil && isIndented(rawLine) {
+			lastEntry.value += "\n" + stripInlineComment(trimmed, opts)
+			continue
+		}
+
+		// section
+		if trimmed[0] == '[' {
+			header := trimmed
+			if opts.AllowInlineComment {
+				header = stripInlineComment(header, opts)
+			}
+			ln := len(header)
+
+			if ln <= 2 || header[ln-1] != ']' {
+				return nil, errors.New("Invalid section at line " + strconv.Itoa(lineNumber))
+			}
+
+			sectionName := fold(strings.TrimSpace(header[1 : ln-1]))
+			curSection = f.getOrCreateSection(sectionName)
+			curSection.comments = append(curSection.comments, pending...)
+			pending = nil
+			lastEntry = nil
+			continue
+		}
+
+		// key
+		n := strings.IndexAny(trimmed, delims)
+		if n < 0 {
+			return nil, errors.New("Invalid format at line " + strconv.Itoa(lineNumber))
+		}
+
+		if curSection == nil {
+			return nil, errors.New("Key without section at line " + strconv.Itoa(lineNumber))
+		}
+
+		keyName := fold(strings.TrimSpace(trimmed[:n]))
+		keyValue, err := parseValue(trimmed[n+1:], opts)
+		if err != nil {
+			return nil, errors.New("Invalid value at line " + strconv.Itoa(lineNumber) + ": " + err.Error())
+		}
+
+		if keyName == "" {
+			return nil, errors.New("Empty key at line " + strconv.Itoa(lineNumber))
+		}
+
+		lastEntry = curSection.set(keyName, keyValue, pending)
+		pending = nil
+	}
+
+	f.trailing = pending
+	f.expandEnabled = opts.Expand
+
+	return f, nil
+}
+
+//
+// isIndented reports whether line starts with a space or tab.
+//
+func isIndented(line string) bool {
+	return len(line) > 0 && (line[0] == ' ' || line[0] == '\t')
+}
+
+//
+// stripInlineComment removes a trailing "# ..." or "; ..." comment from a
+// plain (non-quoted) value or section header, when AllowInlineComment is
+// set; otherwise it returns line unchanged.
+//
+func stripInlineComment(line string, opts LoadOptions) string {
+	if !opts.AllowInlineComment {
+		return strings.TrimSpace(line)
+	}
+
+	n := strings.IndexAny(line, "#;")
+	if n >= 0 {
+		line = line[:n]
+	}
+
+	return strings.TrimSpace(line)
+}
+
+//
+// parseValue interprets the right-hand side of a key/value line: a quoted
+// string (if AllowQuotedValues is set and the value is actually quoted) is
+// taken verbatim with escapes resolved, otherwise the value is trimmed and,
+// if AllowInlineComment is set, truncated at the first '#' or ';'.
+//
+func parseValue(raw string, opts LoadOptions) (string, error) {
+	trimmed := strings.TrimSpace(raw)
+
+	if opts.AllowQuotedValues && len(trimmed) >= 2 {
+		quote := trimmed[0]
+		if quote == '"' || quote == '\'' {
+			return parseQuotedValue(trimmed, quote)
+		}
+	}
+
+	return stripInlineComment(trimmed, opts), nil
+}
+
+//
+// parseQuotedValue unescapes the content of a quoted value. value must
+// start with quote; everything from the matching unescaped closing quote
+// onward (trailing comments included) is discarded.
+//
+func parseQuotedValue(value string, quote byte) (string, error) {
+	var out strings.Builder
+	closed := false
+
+	for i := 1; i < len(value); i++ {
+		c := value[i]
+
+		if c == '\\' && i+1 < len(value) {
+			i++
+			switch value[i] {
+			case 'n':
+				out.WriteByte('\n')
+			case 't':
+				out.WriteByte('\t')
+			case '\\':
+				out.WriteByte('\\')
+			case '"':
+				out.WriteByte('"')
+			case '\'':
+				out.WriteByte('\'')
+			default:
+				out.WriteByte('\\')
+				out.WriteByte(value[i])
+			}
+			continue
+		}
+
+		if c == quote {
+			closed = true
+			break
+		}
+
+		out.WriteByte(c)
+	}
+
+	if !closed {
+		return "", errors.New("unterminated quoted value")
+	}
+
+	return out.String(), nil
+}
@@ -0,0 +1,121 @@
+package gini
+
+import (
+	"strings"
+	"testing"
+)
+
+//
+// TestCaseSensitive
+//
+func TestCaseSensitive(t *testing.T) {
+	src := "[Server]\nHost = localhost\n"
+
+	opts := DefaultLoadOptions()
+	opts.CaseSensitive = true
+
+	ini, err := LoadReaderWithOptions(strings.NewReader(src), opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	chkkey(t, ini, "Server", "Host", "localhost")
+	if ini.SectionExists("server") {
+		t.Error("case-sensitive load should not fold the section name")
+	}
+}
+
+//
+// TestKeyValueDelimiters
+//
+func TestKeyValueDelimiters(t *testing.T) {
+	src := "[server]\nhost: localhost\nport = 8080\n"
+
+	opts := DefaultLoadOptions()
+	opts.KeyValueDelimiters = "=:"
+
+	ini, err := LoadReaderWithOptions(strings.NewReader(src), opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	chkkey(t, ini, "server", "host", "localhost")
+	chkkey(t, ini, "server", "port", "8080")
+}
+
+//
+// TestAllowInlineCommentDisabled checks that disabling inline comments
+// keeps a '#' that is part of the value, such as a URL fragment.
+//
+func TestAllowInlineCommentDisabled(t *testing.T) {
+	src := "[a]\nurl = http://example.com/x#frag\n"
+
+	opts := DefaultLoadOptions()
+	opts.AllowInlineComment = false
+
+	ini, err := LoadReaderWithOptions(strings.NewReader(src), opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	chkkey(t, ini, "a", "url", "http://example.com/x#frag")
+}
+
+//
+// TestAllowPythonMultilineValues
+//
+func TestAllowPythonMultilineValues(t *testing.T) {
+	src := "[a]\nmsg = line one\n  line two\n  line three\n"
+
+	opts := DefaultLoadOptions()
+	opts.AllowPythonMultilineValues = true
+
+	ini, err := LoadReaderWithOptions(strings.NewReader(src), opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	chkkey(t, ini, "a", "msg", "line one\nline two\nline three")
+}
+
+//
+// TestAllowQuotedValues
+//
+func TestAllowQuotedValues(t *testing.T) {
+	src := "[a]\nplain = \"  has # and ; and spaces  \"\nescaped = \"line1\\nline2\"\nsingle = 'it\\'s fine'\n"
+
+	opts := DefaultLoadOptions()
+	opts.AllowQuotedValues = true
+
+	ini, err := LoadReaderWithOptions(strings.NewReader(src), opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	chkkey(t, ini, "a", "plain", "  has # and ; and spaces  ")
+	chkkey(t, ini, "a", "escaped", "line1\nline2")
+	chkkey(t, ini, "a", "single", "it's fine")
+}
+
+//
+// TestDefaultLoadOptionsMatchesLegacyBehaviour checks that LoadReader and
+// LoadReaderWithOptions(DefaultLoadOptions()) parse identically.
+//
+func TestDefaultLoadOptionsMatchesLegacyBehaviour(t *testing.T) {
+	src := "[Kamus]\nMakan = eat # translation\n"
+
+	a, err := LoadReader(strings.NewReader(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := LoadReaderWithOptions(strings.NewReader(src), DefaultLoadOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if a.Read("kamus", "makan") != b.Read("kamus", "makan") {
+		t.Errorf("expected identical results, got %q vs %q", a.Read("kamus", "makan"), b.Read("kamus", "makan"))
+	}
+	chkkey(t, b, "kamus", "makan", "eat")
+}
@@ -0,0 +1,314 @@
+package gini
+
+import (
+	"errors"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//
+// tagName is the struct tag read by MapTo and ReflectFrom.
+// A field tagged `ini:"-"` is ignored; an untagged field falls back to its
+// own name, lower-cased, as the section or key name.
+//
+const tagName = "ini"
+
+//
+// layoutTagName is the struct tag that overrides the time.Time layout used
+// for a field, e.g. `ini-layout:"2006-01-02"`. Defaults to time.RFC3339.
+//
+const layoutTagName = "ini-layout"
+
+//
+// sepTagName is the struct tag that overrides the delimiter used to split
+// and join a slice field's value, e.g. `ini-sep:"|"`. Defaults to ",".
+//
+const sepTagName = "ini-sep"
+
+const defaultTimeLayout = time.RFC3339
+const defaultSliceSep = ","
+
+//
+// fieldName returns the section/key name to use for a struct field, and
+// whether the field should be mapped at all.
+//
+func fieldName(field reflect.StructField) (string, bool) {
+	tag, ok := field.Tag.Lookup(tagName)
+	if ok {
+		if tag == "-" {
+			return "", false
+		}
+		if tag != "" {
+			return tag, true
+		}
+	}
+
+	if field.PkgPath != "" {
+		// unexported field
+		return "", false
+	}
+
+	return strings.ToLower(field.Name), true
+}
+
+//
+// fieldLayout returns the time.Time layout to use for field, from its
+// `ini-layout:"..."` tag, or defaultTimeLayout if untagged.
+//
+func fieldLayout(field reflect.StructField) string {
+	if layout, ok := field.Tag.Lookup(layoutTagName); ok && layout != "" {
+		return layout
+	}
+	return defaultTimeLayout
+}
+
+//
+// fieldSep returns the slice delimiter to use for field, from its
+// `ini-sep:"..."` tag, or defaultSliceSep if untagged.
+//
+func fieldSep(field reflect.StructField) string {
+	if sep, ok := field.Tag.Lookup(sepTagName); ok && sep != "" {
+		return sep
+	}
+	return defaultSliceSep
+}
+
+//
+// MapTo maps the configuration into the struct pointed to by v. Scalar
+// fields of v are read from the "default" section, while nested struct
+// fields are read from a section named after the field (or its
+// `ini:"..."` tag).
+//
+// Supported field kinds are string, bool, every int/uint width, float32/64,
+// time.Duration, time.Time (using RFC3339 by default - override per field
+// with an `ini-layout:"..."` tag) and slices of any of those (split on a
+// comma by default - override per field with an `ini-sep:"..."` tag).
+// Unknown keys in the INI are ignored; missing keys leave the field at its
+// zero value. v must be a non-nil pointer to a struct.
+//
+func (f *Ini) MapTo(v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return errors.New("gini: MapTo requires a non-nil pointer to a struct")
+	}
+
+	return mapStruct(f, "default", rv.Elem())
+}
+
+//
+// mapStruct fills rv (a struct) from sectionName, recursing into nested
+// struct fields using the field name as a child section name.
+//
+func mapStruct(f *Ini, sectionName string, rv reflect.Value) error {
+	rt := rv.Type()
+
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		name, ok := fieldName(field)
+		if !ok {
+			continue
+		}
+
+		fv := rv.Field(i)
+
+		if fv.Kind() == reflect.Struct && fv.Type() != reflect.TypeOf(time.Time{}) {
+			if err := mapStruct(f, name, fv); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if !f.KeyExists(sectionName, name) {
+			continue
+		}
+
+		raw := f.Read(sectionName, name)
+		if err := setField(fv, raw, fieldLayout(field), fieldSep(field)); err != nil {
+			return errors.New("gini: " + sectionName + "." + name + ": " + err.Error())
+		}
+	}
+
+	return nil
+}
+
+//
+// parseBool accepts the usual truthy set used by INI files in the wild:
+// true/false, yes/no, on/off and 1/0, case-insensitive.
+//
+func parseBool(raw string) (bool, error) {
+	switch strings.ToLower(strings.TrimSpace(raw)) {
+	case "true", "yes", "on", "1":
+		return true, nil
+	case "false", "no", "off", "0":
+		return false, nil
+	}
+
+	return false, errors.New("invalid boolean value " + strconv.Quote(raw))
+}
+
+//
+// setField converts raw into the Go value held by fv, in place. layout is
+// the time.Time parse layout and sep is the slice delimiter, both taken
+// from the field's tags (see fieldLayout/fieldSep).
+//
+func setField(fv reflect.Value, raw, layout, sep string) error {
+	if fv.Type() == reflect.TypeOf(time.Duration(0)) {
+		d, err := time.ParseDuration(raw)
+		if err != nil {
+			return err
+		}
+		fv.SetInt(int64(d))
+		return nil
+	}
+
+	if fv.Type() == reflect.TypeOf(time.Time{}) {
+		t, err := time.Parse(layout, raw)
+		if err != nil {
+			return err
+		}
+		fv.Set(reflect.ValueOf(t))
+		return nil
+	}
+
+	switch fv.Kind() {
+	case reflect.String:
+		fv.SetString(raw)
+
+	case reflect.Bool:
+		b, err := parseBool(raw)
+		if err != nil {
+			return err
+		}
+		fv.SetBool(b)
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return err
+		}
+		fv.SetInt(n)
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(raw, 10, 64)
+		if err != nil {
+			return err
+		}
+		fv.SetUint(n)
+
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return err
+		}
+		fv.SetFloat(n)
+
+	case reflect.Slice:
+		var parts []string
+		for _, p := range strings.Split(raw, sep) {
+			parts = append(parts, strings.TrimSpace(p))
+		}
+		slice := reflect.MakeSlice(fv.Type(), len(parts), len(parts))
+		for i, p := range parts {
+			if err := setField(slice.Index(i), p, layout, sep); err != nil {
+				return err
+			}
+		}
+		fv.Set(slice)
+
+	default:
+		return errors.New("unsupported field kind " + fv.Kind().String())
+	}
+
+	return nil
+}
+
+//
+// ReflectFrom builds a new *Ini from the struct pointed to by v, using the
+// same `ini:"..."` tag convention and section layout as MapTo.
+//
+func ReflectFrom(v interface{}) (*Ini, error) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return nil, errors.New("gini: ReflectFrom requires a non-nil pointer to a struct")
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil, errors.New("gini: ReflectFrom requires a struct or pointer to a struct")
+	}
+
+	f := NewIni()
+	if err := reflectStruct(f, "default", rv); err != nil {
+		return nil, err
+	}
+
+	return f, nil
+}
+
+//
+// reflectStruct writes rv's fields into sectionName, recursing into nested
+// struct fields using the field name as a child section name.
+//
+func reflectStruct(f *Ini, sectionName string, rv reflect.Value) error {
+	rt := rv.Type()
+
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		name, ok := fieldName(field)
+		if !ok {
+			continue
+		}
+
+		fv := rv.Field(i)
+
+		if fv.Kind() == reflect.Struct && fv.Type() != reflect.TypeOf(time.Time{}) {
+			if err := reflectStruct(f, name, fv); err != nil {
+				return err
+			}
+			continue
+		}
+
+		f.Set(sectionName, name, formatField(fv, fieldLayout(field), fieldSep(field)))
+	}
+
+	return nil
+}
+
+//
+// formatField renders fv back to its INI string representation. layout is
+// the time.Time format layout and sep is the slice delimiter, both taken
+// from the field's tags (see fieldLayout/fieldSep).
+//
+func formatField(fv reflect.Value, layout, sep string) string {
+	if fv.Type() == reflect.TypeOf(time.Duration(0)) {
+		return time.Duration(fv.Int()).String()
+	}
+
+	if fv.Type() == reflect.TypeOf(time.Time{}) {
+		return fv.Interface().(time.Time).Format(layout)
+	}
+
+	switch fv.Kind() {
+	case reflect.String:
+		return fv.String()
+	case reflect.Bool:
+		return strconv.FormatBool(fv.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(fv.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(fv.Uint(), 10)
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(fv.Float(), 'g', -1, 64)
+	case reflect.Slice:
+		var parts []string
+		for i := 0; i < fv.Len(); i++ {
+			parts = append(parts, formatField(fv.Index(i), layout, sep))
+		}
+		return strings.Join(parts, sep)
+	}
+
+	return ""
+}
@@ -0,0 +1,171 @@
+package gini
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+type serverConfig struct {
+	Host    string
+	Timeout time.Duration
+}
+
+type appConfig struct {
+	Name    string
+	Age     int
+	Ignored string `ini:"-"`
+	Server  serverConfig
+}
+
+//
+// TestMapTo
+//
+func TestMapTo(t *testing.T) {
+	src := "[default]\nname = bob\nage = 30\nignored = should-not-be-mapped\n" +
+		"[server]\nhost = 10.0.0.1\ntimeout = 5s\n"
+
+	ini, err := LoadReader(strings.NewReader(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var cfg appConfig
+	if err := ini.MapTo(&cfg); err != nil {
+		t.Fatal(err)
+	}
+
+	if cfg.Name != "bob" {
+		t.Errorf("Name: expect bob, got %s", cfg.Name)
+	}
+	if cfg.Age != 30 {
+		t.Errorf("Age: expect 30, got %d", cfg.Age)
+	}
+	if cfg.Ignored != "" {
+		t.Errorf("Ignored: expect zero value, got %s", cfg.Ignored)
+	}
+	if cfg.Server.Host != "10.0.0.1" {
+		t.Errorf("Server.Host: expect 10.0.0.1, got %s", cfg.Server.Host)
+	}
+	if cfg.Server.Timeout != 5*time.Second {
+		t.Errorf("Server.Timeout: expect 5s, got %s", cfg.Server.Timeout)
+	}
+}
+
+//
+// TestMapToRequiresPointerToStruct
+//
+func TestMapToRequiresPointerToStruct(t *testing.T) {
+	ini := NewIni()
+
+	var notAPointer appConfig
+	if err := ini.MapTo(notAPointer); err == nil {
+		t.Error("expected an error when v is not a pointer")
+	}
+
+	var nilPointer *appConfig
+	if err := ini.MapTo(nilPointer); err == nil {
+		t.Error("expected an error when v is a nil pointer")
+	}
+}
+
+//
+// TestReflectFrom
+//
+func TestReflectFrom(t *testing.T) {
+	cfg := appConfig{
+		Name: "alice",
+		Age:  25,
+		Server: serverConfig{
+			Host:    "10.0.0.2",
+			Timeout: 10 * time.Second,
+		},
+	}
+
+	ini, err := ReflectFrom(&cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	chkkey(t, ini, "default", "name", "alice")
+	chkkey(t, ini, "default", "age", "25")
+	chkkey(t, ini, "server", "host", "10.0.0.2")
+	chkkey(t, ini, "server", "timeout", "10s")
+
+	if ini.KeyExists("default", "ignored") {
+		t.Error("field tagged ini:\"-\" should not be written")
+	}
+}
+
+type timeLayoutConfig struct {
+	Created time.Time `ini-layout:"2006-01-02"`
+	Tags    []string  `ini-sep:"|"`
+}
+
+//
+// TestMapToAndReflectFromWithTags checks that ini-layout and ini-sep tags
+// override the default RFC3339 time layout and comma slice delimiter.
+//
+func TestMapToAndReflectFromWithTags(t *testing.T) {
+	src := "[default]\ncreated = 2024-01-02\ntags = a|b|c\n"
+
+	ini, err := LoadReader(strings.NewReader(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var cfg timeLayoutConfig
+	if err := ini.MapTo(&cfg); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := cfg.Created.Format("2006-01-02"); got != "2024-01-02" {
+		t.Errorf("Created: expect 2024-01-02, got %s", got)
+	}
+	if err := sliceCompare(cfg.Tags, []string{"a", "b", "c"}); err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := ReflectFrom(&cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	chkkey(t, out, "default", "created", "2024-01-02")
+	chkkey(t, out, "default", "tags", "a|b|c")
+}
+
+type replicaConfig struct {
+	Host    string
+	Timeout int
+}
+
+type dbConfig struct {
+	Replica replicaConfig `ini:"db.replica"`
+}
+
+//
+// TestMapToFallsBackToParentSection checks that MapTo follows the same
+// `parent.child` fallback chain as Read (see inherit.go) for a nested
+// struct field mapped to a child section.
+//
+func TestMapToFallsBackToParentSection(t *testing.T) {
+	src := "[db]\ntimeout = 5\n[db.replica]\nhost = replica-host\n"
+
+	ini, err := LoadReader(strings.NewReader(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var cfg dbConfig
+	if err := ini.MapTo(&cfg); err != nil {
+		t.Fatal(err)
+	}
+
+	if cfg.Replica.Host != "replica-host" {
+		t.Errorf("Replica.Host: got %s", cfg.Replica.Host)
+	}
+	if cfg.Replica.Timeout != 5 {
+		t.Errorf("Replica.Timeout: expected fallback to [db], got %d", cfg.Replica.Timeout)
+	}
+}
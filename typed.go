@@ -0,0 +1,287 @@
+package gini
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+//
+// Int reads a value as an int. Return an error if the key is missing or the
+// value is not a valid integer.
+//
+func (f *Ini) Int(sectionName, keyName string) (int, error) {
+	n, err := f.Int64(sectionName, keyName)
+	return int(n), err
+}
+
+//
+// Int64 reads a value as an int64. Return an error if the key is missing or
+// the value is not a valid integer.
+//
+func (f *Ini) Int64(sectionName, keyName string) (int64, error) {
+	raw, err := f.mustRead(sectionName, keyName)
+	if err != nil {
+		return 0, err
+	}
+
+	return strconv.ParseInt(raw, 10, 64)
+}
+
+//
+// Uint64 reads a value as an uint64. Return an error if the key is missing
+// or the value is not a valid unsigned integer.
+//
+func (f *Ini) Uint64(sectionName, keyName string) (uint64, error) {
+	raw, err := f.mustRead(sectionName, keyName)
+	if err != nil {
+		return 0, err
+	}
+
+	return strconv.ParseUint(raw, 10, 64)
+}
+
+//
+// Float64 reads a value as a float64. Return an error if the key is missing
+// or the value is not a valid float.
+//
+func (f *Ini) Float64(sectionName, keyName string) (float64, error) {
+	raw, err := f.mustRead(sectionName, keyName)
+	if err != nil {
+		return 0, err
+	}
+
+	return strconv.ParseFloat(raw, 64)
+}
+
+//
+// Bool reads a value as a bool, accepting the usual truthy set:
+// true/false, yes/no, on/off and 1/0, case-insensitive. Return an error if
+// the key is missing or the value is not one of those.
+//
+func (f *Ini) Bool(sectionName, keyName string) (bool, error) {
+	raw, err := f.mustRead(sectionName, keyName)
+	if err != nil {
+		return false, err
+	}
+
+	return parseBool(raw)
+}
+
+//
+// Duration reads a value as a time.Duration, using time.ParseDuration.
+// Return an error if the key is missing or the value is not a valid
+// duration.
+//
+func (f *Ini) Duration(sectionName, keyName string) (time.Duration, error) {
+	raw, err := f.mustRead(sectionName, keyName)
+	if err != nil {
+		return 0, err
+	}
+
+	return time.ParseDuration(raw)
+}
+
+//
+// Time reads a value as a time.Time, parsed with layout. Return an error if
+// the key is missing or the value does not match layout.
+//
+func (f *Ini) Time(sectionName, keyName, layout string) (time.Time, error) {
+	raw, err := f.mustRead(sectionName, keyName)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return time.Parse(layout, raw)
+}
+
+//
+// StringSlice splits a value on sep, trimming whitespace from each part.
+// Return nil if the key is missing or empty.
+//
+func (f *Ini) StringSlice(sectionName, keyName, sep string) []string {
+	raw := f.Read(sectionName, keyName)
+	if raw == "" {
+		return nil
+	}
+
+	parts := strings.Split(raw, sep)
+	for i, p := range parts {
+		parts[i] = strings.TrimSpace(p)
+	}
+
+	return parts
+}
+
+//
+// mustRead returns the raw value of sectionName/keyName, or an error if the
+// key does not exist.
+//
+func (f *Ini) mustRead(sectionName, keyName string) (string, error) {
+	if !f.KeyExists(sectionName, keyName) {
+		return "", newKeyNotFoundError(sectionName, keyName)
+	}
+
+	return f.Read(sectionName, keyName), nil
+}
+
+//
+// IntDefault reads a value as an int, returning def if the key is missing
+// or the value cannot be parsed.
+//
+func (f *Ini) IntDefault(sectionName, keyName string, def int) int {
+	v, err := f.Int(sectionName, keyName)
+	if err != nil {
+		return def
+	}
+	return v
+}
+
+//
+// Int64Default reads a value as an int64, returning def if the key is
+// missing or the value cannot be parsed.
+//
+func (f *Ini) Int64Default(sectionName, keyName string, def int64) int64 {
+	v, err := f.Int64(sectionName, keyName)
+	if err != nil {
+		return def
+	}
+	return v
+}
+
+//
+// Uint64Default reads a value as an uint64, returning def if the key is
+// missing or the value cannot be parsed.
+//
+func (f *Ini) Uint64Default(sectionName, keyName string, def uint64) uint64 {
+	v, err := f.Uint64(sectionName, keyName)
+	if err != nil {
+		return def
+	}
+	return v
+}
+
+//
+// Float64Default reads a value as a float64, returning def if the key is
+// missing or the value cannot be parsed.
+//
+func (f *Ini) Float64Default(sectionName, keyName string, def float64) float64 {
+	v, err := f.Float64(sectionName, keyName)
+	if err != nil {
+		return def
+	}
+	return v
+}
+
+//
+// BoolDefault reads a value as a bool, returning def if the key is missing
+// or the value cannot be parsed.
+//
+func (f *Ini) BoolDefault(sectionName, keyName string, def bool) bool {
+	v, err := f.Bool(sectionName, keyName)
+	if err != nil {
+		return def
+	}
+	return v
+}
+
+//
+// DurationDefault reads a value as a time.Duration, returning def if the
+// key is missing or the value cannot be parsed.
+//
+func (f *Ini) DurationDefault(sectionName, keyName string, def time.Duration) time.Duration {
+	v, err := f.Duration(sectionName, keyName)
+	if err != nil {
+		return def
+	}
+	return v
+}
+
+//
+// TimeDefault reads a value as a time.Time parsed with layout, returning
+// def if the key is missing or the value cannot be parsed.
+//
+func (f *Ini) TimeDefault(sectionName, keyName, layout string, def time.Time) time.Time {
+	v, err := f.Time(sectionName, keyName, layout)
+	if err != nil {
+		return def
+	}
+	return v
+}
+
+//
+// MustInt is like Int but panics instead of returning an error.
+//
+func (f *Ini) MustInt(sectionName, keyName string) int {
+	v, err := f.Int(sectionName, keyName)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+//
+// MustInt64 is like Int64 but panics instead of returning an error.
+//
+func (f *Ini) MustInt64(sectionName, keyName string) int64 {
+	v, err := f.Int64(sectionName, keyName)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+//
+// MustUint64 is like Uint64 but panics instead of returning an error.
+//
+func (f *Ini) MustUint64(sectionName, keyName string) uint64 {
+	v, err := f.Uint64(sectionName, keyName)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+//
+// MustFloat64 is like Float64 but panics instead of returning an error.
+//
+func (f *Ini) MustFloat64(sectionName, keyName string) float64 {
+	v, err := f.Float64(sectionName, keyName)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+//
+// MustBool is like Bool but panics instead of returning an error.
+//
+func (f *Ini) MustBool(sectionName, keyName string) bool {
+	v, err := f.Bool(sectionName, keyName)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+//
+// MustDuration is like Duration but panics instead of returning an error.
+//
+func (f *Ini) MustDuration(sectionName, keyName string) time.Duration {
+	v, err := f.Duration(sectionName, keyName)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+//
+// MustTime is like Time but panics instead of returning an error.
+//
+func (f *Ini) MustTime(sectionName, keyName, layout string) time.Time {
+	v, err := f.Time(sectionName, keyName, layout)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
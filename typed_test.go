@@ -0,0 +1,154 @@
+package gini
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+var typedIni = `
+[a]
+int = 42
+neg = -7
+u64 = 18446744073709551615
+flt = 3.14
+bool_true = yes
+bool_false = Off
+dur = 1h30m
+ts = 2024-01-02T15:04:05Z
+list = a, b ,c
+bad = not-a-number
+`
+
+func loadTyped(t *testing.T) *Ini {
+	t.Helper()
+	ini, err := LoadReader(strings.NewReader(typedIni))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return ini
+}
+
+//
+// TestTypedAccessors
+//
+func TestTypedAccessors(t *testing.T) {
+	ini := loadTyped(t)
+
+	if v, err := ini.Int("a", "int"); err != nil || v != 42 {
+		t.Errorf("Int: got (%d, %v)", v, err)
+	}
+	if v, err := ini.Int64("a", "neg"); err != nil || v != -7 {
+		t.Errorf("Int64: got (%d, %v)", v, err)
+	}
+	if v, err := ini.Uint64("a", "u64"); err != nil || v != 18446744073709551615 {
+		t.Errorf("Uint64: got (%d, %v)", v, err)
+	}
+	if v, err := ini.Float64("a", "flt"); err != nil || v != 3.14 {
+		t.Errorf("Float64: got (%v, %v)", v, err)
+	}
+	if v, err := ini.Bool("a", "bool_true"); err != nil || v != true {
+		t.Errorf("Bool(yes): got (%v, %v)", v, err)
+	}
+	if v, err := ini.Bool("a", "bool_false"); err != nil || v != false {
+		t.Errorf("Bool(Off): got (%v, %v)", v, err)
+	}
+	if v, err := ini.Duration("a", "dur"); err != nil || v != 90*time.Minute {
+		t.Errorf("Duration: got (%v, %v)", v, err)
+	}
+	if v, err := ini.Time("a", "ts", time.RFC3339); err != nil || !v.Equal(time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC)) {
+		t.Errorf("Time: got (%v, %v)", v, err)
+	}
+
+	if err := sliceCompare(ini.StringSlice("a", "list", ","), []string{"a", "b", "c"}); err != nil {
+		t.Fatal(err)
+	}
+	if got := ini.StringSlice("a", "missing", ","); got != nil {
+		t.Errorf("StringSlice(missing): expect nil, got %v", got)
+	}
+
+	if _, err := ini.Int("a", "bad"); err == nil {
+		t.Error("Int(bad): expected an error")
+	}
+	if _, err := ini.Int("a", "missing"); err == nil {
+		t.Error("Int(missing): expected an error")
+	}
+}
+
+//
+// TestTypedDefaults
+//
+func TestTypedDefaults(t *testing.T) {
+	ini := loadTyped(t)
+
+	if v := ini.IntDefault("a", "int", -1); v != 42 {
+		t.Errorf("IntDefault(present): got %d", v)
+	}
+	if v := ini.IntDefault("a", "missing", -1); v != -1 {
+		t.Errorf("IntDefault(missing): got %d", v)
+	}
+	if v := ini.BoolDefault("a", "missing", true); v != true {
+		t.Errorf("BoolDefault(missing): got %v", v)
+	}
+	if v := ini.DurationDefault("a", "missing", time.Second); v != time.Second {
+		t.Errorf("DurationDefault(missing): got %v", v)
+	}
+}
+
+//
+// TestMustAccessorsPanicOnMissing
+//
+func TestMustAccessorsPanicOnMissing(t *testing.T) {
+	ini := loadTyped(t)
+
+	defer func() {
+		if recover() == nil {
+			t.Error("MustInt: expected a panic for a missing key")
+		}
+	}()
+
+	ini.MustInt("a", "missing")
+}
+
+//
+// TestMustAccessorsSucceed
+//
+func TestMustAccessorsSucceed(t *testing.T) {
+	ini := loadTyped(t)
+
+	if v := ini.MustInt("a", "int"); v != 42 {
+		t.Errorf("MustInt: got %d", v)
+	}
+	if v := ini.MustBool("a", "bool_true"); v != true {
+		t.Errorf("MustBool: got %v", v)
+	}
+}
+
+//
+// TestTypedAccessorFallsBackToParentSection checks that a typed accessor
+// follows the same `parent.child` fallback chain as Read (see inherit.go)
+// instead of reporting a key declared only in an ancestor section as
+// missing.
+//
+func TestTypedAccessorFallsBackToParentSection(t *testing.T) {
+	src := "[db]\ntimeout = 5\n[db.replica]\nhost = replica-host\n"
+
+	ini, err := LoadReader(strings.NewReader(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !ini.KeyExists("db.replica", "timeout") {
+		t.Fatal("KeyExists should see timeout via the db.replica -> db fallback")
+	}
+
+	if v, err := ini.Int("db.replica", "timeout"); err != nil || v != 5 {
+		t.Errorf("Int: got (%d, %v)", v, err)
+	}
+	if v := ini.IntDefault("db.replica", "timeout", -1); v != 5 {
+		t.Errorf("IntDefault: got %d", v)
+	}
+	if v := ini.MustInt("db.replica", "timeout"); v != 5 {
+		t.Errorf("MustInt: got %d", v)
+	}
+}
@@ -0,0 +1,184 @@
+package gini
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"strings"
+)
+
+//
+// NewIni creates an empty, ready to use *Ini, for building a configuration
+// from scratch and saving it with SaveFile or WriteTo.
+//
+func NewIni() *Ini {
+	return &Ini{index: make(map[string]int)}
+}
+
+//
+// Set a value on the specified sectionName and keyName, creating the section
+// and/or key if they do not exist yet. Existing comments attached to the key
+// are preserved; a newly created key has none.
+//
+func (f *Ini) Set(sectionName, keyName, value string) {
+	sect := f.getOrCreateSection(sectionName)
+	sect.set(keyName, value, nil)
+}
+
+//
+// DeleteKey removes a key from the specified section.
+// Return `true` if the key existed and was removed
+//
+func (f *Ini) DeleteKey(sectionName, keyName string) bool {
+	sect, fnd := f.getSection(sectionName)
+	if !fnd {
+		return false
+	}
+
+	return sect.delete(keyName)
+}
+
+//
+// DeleteSection removes a section and all of its keys.
+// Return `true` if the section existed and was removed
+//
+func (f *Ini) DeleteSection(sectionName string) bool {
+	i, fnd := f.index[sectionName]
+	if !fnd {
+		return false
+	}
+
+	f.sections = append(f.sections[:i], f.sections[i+1:]...)
+	delete(f.index, sectionName)
+	for k, idx := range f.index {
+		if idx > i {
+			f.index[k] = idx - 1
+		}
+	}
+	return true
+}
+
+//
+// WriteTo writes the configuration to w, in section/key declaration order,
+// reproducing the comment and blank lines captured when the file was loaded.
+// Implements io.WriterTo.
+//
+func (f *Ini) WriteTo(w io.Writer) (int64, error) {
+	var n int64
+	bufw := bufio.NewWriter(w)
+
+	write := func(s string) error {
+		wn, err := bufw.WriteString(s)
+		n += int64(wn)
+		return err
+	}
+
+	for _, s := range f.sections {
+		for _, c := range s.comments {
+			if err := write(c + "\n"); err != nil {
+				return n, err
+			}
+		}
+
+		if err := write("[" + s.name + "]\n"); err != nil {
+			return n, err
+		}
+
+		for _, e := range s.entries {
+			for _, c := range e.comments {
+				if err := write(c + "\n"); err != nil {
+					return n, err
+				}
+			}
+
+			if err := write(e.key + " = " + writeValue(e.value) + "\n"); err != nil {
+				return n, err
+			}
+		}
+	}
+
+	for _, c := range f.trailing {
+		if err := write(c + "\n"); err != nil {
+			return n, err
+		}
+	}
+
+	if err := bufw.Flush(); err != nil {
+		return n, err
+	}
+
+	return n, nil
+}
+
+//
+// writeValue renders value the way it needs to appear on a "key = ..."
+// line so that reloading it - even through plain LoadReader/LoadFile,
+// whose DefaultLoadOptions has AllowQuotedValues on for exactly this
+// reason - reproduces the exact value: it is quoted if it contains '#',
+// ';', a newline, or leading/trailing whitespace, or starts with a quote
+// character, any of which would otherwise corrupt or truncate it on the
+// next load.
+//
+func writeValue(value string) string {
+	if !needsQuoting(value) {
+		return value
+	}
+
+	var b strings.Builder
+	b.WriteByte('"')
+
+	for i := 0; i < len(value); i++ {
+		switch c := value[i]; c {
+		case '\\':
+			b.WriteString(`\\`)
+		case '"':
+			b.WriteString(`\"`)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\t':
+			b.WriteString(`\t`)
+		default:
+			b.WriteByte(c)
+		}
+	}
+
+	b.WriteByte('"')
+	return b.String()
+}
+
+//
+// needsQuoting reports whether value would be altered by the parser if
+// written back out unquoted: an inline comment marker, an embedded
+// newline, leading/trailing whitespace trimmed by TrimSpace, or a leading
+// quote character that the parser would take as the start of a quoted value.
+//
+func needsQuoting(value string) bool {
+	if value == "" {
+		return false
+	}
+
+	if strings.TrimSpace(value) != value {
+		return true
+	}
+
+	if value[0] == '"' || value[0] == '\'' {
+		return true
+	}
+
+	return strings.ContainsAny(value, "#;\n\r")
+}
+
+//
+// SaveFile writes the configuration to the file at path, creating it if it
+// does not exist and truncating it otherwise.
+//
+func (f *Ini) SaveFile(path string) error {
+	out, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	_, err = f.WriteTo(out)
+	return err
+}
@@ -0,0 +1,175 @@
+package gini
+
+import (
+	"strings"
+	"testing"
+)
+
+//
+// TestNewIniSetAndRead
+//
+func TestNewIniSetAndRead(t *testing.T) {
+	ini := NewIni()
+	ini.Set("server", "host", "localhost")
+	ini.Set("server", "port", "8080")
+
+	chkkey(t, ini, "server", "host", "localhost")
+	chkkey(t, ini, "server", "port", "8080")
+
+	// Set again overrides in place rather than duplicating the key
+	ini.Set("server", "port", "9090")
+	chkkey(t, ini, "server", "port", "9090")
+
+	err := sliceCompare(ini.KeyList("server"), []string{"host", "port"})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+//
+// TestDeleteKeyAndSection
+//
+func TestDeleteKeyAndSection(t *testing.T) {
+	ini := NewIni()
+	ini.Set("a", "x", "1")
+	ini.Set("a", "y", "2")
+	ini.Set("b", "z", "3")
+
+	if !ini.DeleteKey("a", "x") {
+		t.Fatal("expected DeleteKey to report the key existed")
+	}
+	if ini.KeyExists("a", "x") {
+		t.Fatal("key still exists after DeleteKey")
+	}
+	if ini.DeleteKey("a", "x") {
+		t.Fatal("expected DeleteKey to report false for an already-deleted key")
+	}
+
+	if !ini.DeleteSection("b") {
+		t.Fatal("expected DeleteSection to report the section existed")
+	}
+	if ini.SectionExists("b") {
+		t.Fatal("section still exists after DeleteSection")
+	}
+
+	err := sliceCompare(ini.SectionList(), []string{"a"})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+//
+// TestWriteToRoundTrip checks that loading, saving and reloading a file
+// reproduces its sections, keys and comments in declaration order.
+//
+func TestWriteToRoundTrip(t *testing.T) {
+	src := "; top comment\n\n[kamus]\n; about makan\nmakan=eat\nminum=drink\n\n[status]\nweb=active\n"
+
+	ini, err := LoadReader(strings.NewReader(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf strings.Builder
+	if _, err := ini.WriteTo(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	reloaded, err := LoadReader(strings.NewReader(buf.String()))
+	if err != nil {
+		t.Fatalf("reload failed: %v\noutput was:\n%s", err, buf.String())
+	}
+
+	chkkey(t, reloaded, "kamus", "makan", "eat")
+	chkkey(t, reloaded, "kamus", "minum", "drink")
+	chkkey(t, reloaded, "status", "web", "active")
+
+	out := buf.String()
+	if !strings.Contains(out, "; top comment") {
+		t.Errorf("leading file comment was not preserved:\n%s", out)
+	}
+	if !strings.Contains(out, "; about makan") {
+		t.Errorf("key comment was not preserved:\n%s", out)
+	}
+}
+
+//
+// TestWriteToQuotesValuesThatNeedIt checks that a value containing a '#',
+// or produced by AllowPythonMultilineValues, is quoted on save so that
+// reloading it does not truncate or corrupt it.
+//
+func TestWriteToQuotesValuesThatNeedIt(t *testing.T) {
+	opts := DefaultLoadOptions()
+	opts.AllowQuotedValues = true
+	opts.AllowPythonMultilineValues = true
+
+	src := "[a]\nurl = \"http://example.com/x#frag\"\nmulti = line1\n  line2\n"
+
+	ini, err := LoadReaderWithOptions(strings.NewReader(src), opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf strings.Builder
+	if _, err := ini.WriteTo(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	reloaded, err := LoadReaderWithOptions(strings.NewReader(buf.String()), opts)
+	if err != nil {
+		t.Fatalf("reload failed: %v\noutput was:\n%s", err, buf.String())
+	}
+
+	if v := reloaded.Read("a", "url"); v != "http://example.com/x#frag" {
+		t.Errorf("expected url to survive the round-trip, got %q\noutput was:\n%s", v, buf.String())
+	}
+	if v := reloaded.Read("a", "multi"); v != "line1\nline2" {
+		t.Errorf("expected multiline value to survive the round-trip, got %q\noutput was:\n%s", v, buf.String())
+	}
+}
+
+//
+// TestWriteToRoundTripsThroughDefaultLoadReader checks that a value that
+// WriteTo must quote (here, a URL with a '#' fragment) survives a
+// Set -> WriteTo -> LoadReader cycle using the plain default options, not
+// just LoadReaderWithOptions with AllowQuotedValues explicitly set.
+//
+func TestWriteToRoundTripsThroughDefaultLoadReader(t *testing.T) {
+	ini := NewIni()
+	ini.Set("s", "url", "http://example.com/#frag")
+
+	var buf strings.Builder
+	if _, err := ini.WriteTo(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	reloaded, err := LoadReader(strings.NewReader(buf.String()))
+	if err != nil {
+		t.Fatalf("reload failed: %v\noutput was:\n%s", err, buf.String())
+	}
+
+	if v := reloaded.Read("s", "url"); v != "http://example.com/#frag" {
+		t.Errorf("expected url to survive the round-trip through the default LoadReader, got %q\noutput was:\n%s", v, buf.String())
+	}
+}
+
+//
+// TestSaveFile checks that SaveFile writes a file LoadFile can read back.
+//
+func TestSaveFile(t *testing.T) {
+	path := t.TempDir() + "/out.ini"
+
+	ini := NewIni()
+	ini.Set("setting", "color", "red")
+
+	if err := ini.SaveFile(path); err != nil {
+		t.Fatal(err)
+	}
+
+	reloaded, err := LoadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	chkkey(t, reloaded, "setting", "color", "red")
+}